@@ -8,19 +8,110 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
 )
 
-// TmuxWindow defines a tmux window structure.
+// TmuxPane defines a single pane within a tmux window, created by splitting
+// the window (or a previous pane) either horizontally or vertically.
+type TmuxPane struct {
+	Split    string   `json:"split,omitempty" yaml:"split,omitempty" toml:"split,omitempty"` // "horizontal" or "vertical"
+	Dir      string   `json:"dir,omitempty" yaml:"dir,omitempty" toml:"dir,omitempty"`
+	Commands []string `json:"commands,omitempty" yaml:"commands,omitempty" toml:"commands,omitempty"`
+	Zoom     bool     `json:"zoom,omitempty" yaml:"zoom,omitempty" toml:"zoom,omitempty"`
+}
+
+// TmuxWindow defines a tmux window structure. A window with no panes runs
+// its commands directly; a window with panes splits itself according to
+// each pane's Split direction and arranges the result with Layout.
 type TmuxWindow struct {
-	Name     string   `json:"name"`
-	Commands []string `json:"commands"`
+	Name     string     `json:"name" yaml:"name" toml:"name"`
+	Dir      string     `json:"dir,omitempty" yaml:"dir,omitempty" toml:"dir,omitempty"`
+	Layout   string     `json:"layout,omitempty" yaml:"layout,omitempty" toml:"layout,omitempty"` // e.g. "even-horizontal", "main-vertical", "tiled", or a raw tmux layout string
+	Commands []string   `json:"commands,omitempty" yaml:"commands,omitempty" toml:"commands,omitempty"`
+	Panes    []TmuxPane `json:"panes,omitempty" yaml:"panes,omitempty" toml:"panes,omitempty"`
+	// Manual excludes the window from a default start; it is only created
+	// when named explicitly via `-w`.
+	Manual bool `json:"manual,omitempty" yaml:"manual,omitempty" toml:"manual,omitempty"`
 }
 
 // TmuxSession defines the structure of a tmux session.
 type TmuxSession struct {
-	SessionName string       `json:"session_name"`
-	Windows     []TmuxWindow `json:"windows"`
+	SessionName  string       `json:"session_name" yaml:"session_name" toml:"session_name"`
+	Windows      []TmuxWindow `json:"windows" yaml:"windows" toml:"windows"`
+	SelectWindow string       `json:"select_window,omitempty" yaml:"select_window,omitempty" toml:"select_window,omitempty"` // window name to focus on attach
+	SelectPane   string       `json:"select_pane,omitempty" yaml:"select_pane,omitempty" toml:"select_pane,omitempty"`       // pane index (within SelectWindow) to focus on attach
+
+	// OnProjectFirstStart runs once the session has been created, but only
+	// the first time it is started (i.e. never on attach to an existing session).
+	OnProjectFirstStart []string `json:"on_project_first_start,omitempty" yaml:"on_project_first_start,omitempty" toml:"on_project_first_start,omitempty"`
+	// BeforeStart runs before the tmux session itself is created, e.g. to
+	// bring up docker-compose services or a database the windows depend on.
+	BeforeStart []string `json:"before_start,omitempty" yaml:"before_start,omitempty" toml:"before_start,omitempty"`
+	// Stop runs when the session is torn down via `teleport stop`, before
+	// the tmux session is killed.
+	Stop []string `json:"stop,omitempty" yaml:"stop,omitempty" toml:"stop,omitempty"`
+}
+
+// knownLayouts are the built-in tmux layout names accepted in addition to a
+// raw tmux layout string (e.g. copied from `tmux list-windows -F '#{window_layout}'`).
+var knownLayouts = map[string]bool{
+	"even-horizontal": true,
+	"even-vertical":   true,
+	"main-horizontal": true,
+	"main-vertical":   true,
+	"tiled":           true,
+}
+
+// isValidLayout reports whether layout is a known layout name or looks like
+// a raw tmux layout string (checksum,WxH,x,y{...}).
+func isValidLayout(layout string) bool {
+	if knownLayouts[layout] {
+		return true
+	}
+	return strings.Contains(layout, ",")
+}
+
+// validateSession checks a parsed TmuxSession for internal consistency,
+// collecting every problem into a single error instead of failing on the first.
+func validateSession(session *TmuxSession) error {
+	var problems []string
+
+	if strings.TrimSpace(session.SessionName) == "" {
+		problems = append(problems, "session_name must not be empty")
+	}
+	if len(session.Windows) == 0 {
+		problems = append(problems, "session must declare at least one window")
+	}
+
+	windowNames := make(map[string]TmuxWindow, len(session.Windows))
+	for _, window := range session.Windows {
+		windowNames[window.Name] = window
+		if window.Layout != "" && !isValidLayout(window.Layout) {
+			problems = append(problems, fmt.Sprintf("window %q: unknown layout %q", window.Name, window.Layout))
+		}
+	}
+
+	selectWindow, hasSelectWindow := windowNames[session.SelectWindow]
+	if session.SelectWindow != "" && !hasSelectWindow {
+		problems = append(problems, fmt.Sprintf("select_window %q does not match any window", session.SelectWindow))
+	}
+
+	if session.SelectPane != "" {
+		if session.SelectWindow == "" || !hasSelectWindow {
+			problems = append(problems, fmt.Sprintf("select_pane %q requires a matching select_window", session.SelectPane))
+		} else if paneIndex, err := strconv.Atoi(session.SelectPane); err != nil || paneIndex < 0 || paneIndex > len(selectWindow.Panes) {
+			problems = append(problems, fmt.Sprintf("select_pane %q is not a valid pane index for window %q", session.SelectPane, session.SelectWindow))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid session config:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
 }
 
 // ReadConfig reads the .tp.conf file from the home directory.
@@ -50,7 +141,22 @@ func ReadConfig() (map[string]string, error) {
 	return config, nil
 }
 
-// ListScripts searches for .tmux files in the specified directory.
+// scriptSuffixes are the recognized tmux config file extensions, dispatched
+// on by ReadSessionConfig.
+var scriptSuffixes = []string{".tmux", ".tmux.yaml", ".tmux.yml", ".tmux.toml"}
+
+// isScriptFile reports whether name has one of the recognized script suffixes.
+func isScriptFile(name string) bool {
+	for _, suffix := range scriptSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListScripts searches for .tmux, .tmux.yaml/.tmux.yml, and .tmux.toml files
+// in the specified directory.
 func ListScripts(root string) ([]string, error) {
 	var scripts []string
 
@@ -73,7 +179,7 @@ func ListScripts(root string) ([]string, error) {
 			return nil
 		}
 
-		if info.Name() == ".tmux" && !info.IsDir() {
+		if !info.IsDir() && isScriptFile(info.Name()) {
 			scripts = append(scripts, path)
 		}
 		return nil
@@ -104,7 +210,8 @@ func SelectScriptWithFzf(scripts []string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
-// ReadSessionConfig reads the selected tmux session configuration from the .tmux file.
+// ReadSessionConfig reads and validates the tmux session configuration from
+// a .tmux, .tmux.yaml/.tmux.yml, or .tmux.toml file, dispatching on extension.
 func ReadSessionConfig(path string) (*TmuxSession, error) {
 	data, err := ioutil.ReadFile(path)
 	if err != nil {
@@ -112,74 +219,284 @@ func ReadSessionConfig(path string) (*TmuxSession, error) {
 	}
 
 	var session TmuxSession
-	err = json.Unmarshal(data, &session)
+	switch {
+	case strings.HasSuffix(path, ".tmux.yaml"), strings.HasSuffix(path, ".tmux.yml"):
+		err = yaml.Unmarshal(data, &session)
+	case strings.HasSuffix(path, ".tmux.toml"):
+		err = toml.Unmarshal(data, &session)
+	case strings.HasSuffix(path, ".tmux"):
+		err = json.Unmarshal(data, &session)
+	default:
+		return nil, fmt.Errorf("unrecognized tmux config format: %s", path)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("could not parse tmux config: %v", err)
 	}
 
+	if err := validateSession(&session); err != nil {
+		return nil, err
+	}
+
 	return &session, nil
 }
 
-// sessionExists checks if a tmux session with the given name already exists.
-func sessionExists(name string) bool {
-	cmd := exec.Command("tmux", "has-session", "-t", name)
-	err := cmd.Run()
-	return err == nil
+// windowsToStart resolves which of the session's windows should be started.
+// If only is non-empty, it is treated as an explicit allow-list (regardless
+// of Manual); otherwise every non-Manual window is started by default.
+func windowsToStart(session *TmuxSession, only []string) []TmuxWindow {
+	if len(only) > 0 {
+		var selected []TmuxWindow
+		for _, name := range only {
+			for _, window := range session.Windows {
+				if window.Name == name {
+					selected = append(selected, window)
+				}
+			}
+		}
+		return selected
+	}
+
+	var selected []TmuxWindow
+	for _, window := range session.Windows {
+		if !window.Manual {
+			selected = append(selected, window)
+		}
+	}
+	return selected
 }
 
-// createSession creates a new tmux session based on the configuration.
-func createSession(session *TmuxSession, basePath string) error {
-	// Create a new tmux session
-	err := exec.Command("tmux", "new-session", "-d", "-s", session.SessionName).Run()
+// runningWindows returns the names of the windows currently open in session.
+func runningWindows(t Tmux, sessionName string) (map[string]bool, error) {
+	names, err := t.ListWindows(sessionName)
 	if err != nil {
-		return fmt.Errorf("failed to create session: %v", err)
+		return nil, err
 	}
 
-	for i, window := range session.Windows {
-		targetWindow := fmt.Sprintf("%s:%d", session.SessionName, i)
+	running := make(map[string]bool, len(names))
+	for _, name := range names {
+		running[name] = true
+	}
+	return running, nil
+}
 
-		if i == 0 {
-			// Rename the first window in the session
-			err = exec.Command("tmux", "rename-window", "-t", targetWindow, window.Name).Run()
-		} else {
-			// Create new windows for the session
-			err = exec.Command("tmux", "new-window", "-t", session.SessionName, "-n", window.Name).Run()
+// createSession creates a new tmux session based on the configuration, or,
+// if the session already exists, idempotently adds any of the requested
+// windows that aren't already running. only selects which windows to start;
+// see windowsToStart.
+func createSession(t Tmux, session *TmuxSession, basePath string, only []string) error {
+	isNewSession := !t.HasSession(session.SessionName)
+
+	if isNewSession {
+		if len(session.BeforeStart) > 0 {
+			if err := runCommands(session.BeforeStart, basePath); err != nil {
+				return fmt.Errorf("failed to run before_start commands: %v", err)
+			}
+		}
+
+		if err := t.NewSession(session.SessionName); err != nil {
+			return err
 		}
+	}
 
+	existing := map[string]bool{}
+	if !isNewSession {
+		var err error
+		existing, err = runningWindows(t, session.SessionName)
 		if err != nil {
-			return fmt.Errorf("failed to create window: %v", err)
+			return err
+		}
+	}
+
+	firstWindowRenamed := false
+	for _, window := range windowsToStart(session, only) {
+		if existing[window.Name] {
+			continue // already running; skip so windows can be added idempotently
+		}
+
+		targetWindow := fmt.Sprintf("%s:%s", session.SessionName, window.Name)
+		windowDir := basePath
+		if window.Dir != "" {
+			windowDir = filepath.Join(basePath, window.Dir)
+		}
+
+		var err error
+		if isNewSession && !firstWindowRenamed {
+			// Rename the default first window instead of creating a new one
+			err = t.RenameWindow(fmt.Sprintf("%s:0", session.SessionName), window.Name)
+			firstWindowRenamed = true
+		} else {
+			err = t.NewWindow(session.SessionName, window.Name, windowDir)
 		}
 
-		// Change directory in each tmux window to the base path of the selected .tmux file
-		err = exec.Command("tmux", "send-keys", "-t", targetWindow, fmt.Sprintf("cd %s", basePath), "C-m").Run()
 		if err != nil {
-			return fmt.Errorf("failed to change directory in window %d: %v", i, err)
+			return err
 		}
 
-		// Send commands to each window
+		// Change directory in the tmux window to the base path of the selected .tmux file
+		if err := t.SendKeys(targetWindow, fmt.Sprintf("cd %s", windowDir)); err != nil {
+			return err
+		}
+
+		// Send commands to the window's first pane
 		for _, cmdStr := range window.Commands {
-			err = exec.Command("tmux", "send-keys", "-t", targetWindow, cmdStr, "C-m").Run()
-			if err != nil {
-				return fmt.Errorf("failed to send command to window %d: %v", i, err)
+			if err := t.SendKeys(targetWindow, cmdStr); err != nil {
+				return err
 			}
 		}
+
+		if err := createPanes(t, window, targetWindow, windowDir); err != nil {
+			return fmt.Errorf("failed to create panes for window %q: %v", window.Name, err)
+		}
+
+		if window.Layout != "" {
+			if err := t.SelectLayout(targetWindow, window.Layout); err != nil {
+				return err
+			}
+		}
+	}
+
+	if session.SelectWindow != "" {
+		if err := t.SelectWindow(fmt.Sprintf("%s:%s", session.SessionName, session.SelectWindow)); err != nil {
+			return err
+		}
+	}
+
+	if session.SelectPane != "" {
+		target := fmt.Sprintf("%s:%s", session.SessionName, session.SelectWindow)
+		if err := t.SelectPane(fmt.Sprintf("%s.%s", target, session.SelectPane)); err != nil {
+			return err
+		}
+	}
+
+	if isNewSession && len(session.OnProjectFirstStart) > 0 {
+		if err := runCommands(session.OnProjectFirstStart, basePath); err != nil {
+			return fmt.Errorf("failed to run on_project_first_start commands: %v", err)
+		}
 	}
 
 	return nil
 }
 
-// attachSession attaches to an existing tmux session.
-func attachSession(name string) error {
-	fmt.Printf("Attempting to attach to session: %s\n", name)
+// runCommands runs each command through the shell with its working directory
+// set to dir, streaming stdout/stderr through to the caller's.
+func runCommands(commands []string, dir string) error {
+	for _, c := range commands {
+		cmd := exec.Command("sh", "-c", c)
+		cmd.Dir = dir
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("command %q failed: %v", c, err)
+		}
+	}
+	return nil
+}
+
+// stopSession runs the session's stop commands and kills the tmux session.
+func stopSession(t Tmux, session *TmuxSession, basePath string) error {
+	if len(session.Stop) > 0 {
+		if err := runCommands(session.Stop, basePath); err != nil {
+			return fmt.Errorf("failed to run stop commands: %v", err)
+		}
+	}
+
+	return t.KillSession(session.SessionName)
+}
+
+// ScriptEntry pairs a discovered script path with its parsed session config.
+type ScriptEntry struct {
+	Path    string
+	Session *TmuxSession
+}
+
+// Registry holds every valid script found under a TP_DIRECTORY.
+type Registry struct {
+	entries []ScriptEntry
+}
+
+// BuildRegistry walks root for scripts and parses each one, skipping (with a
+// warning) any that fail to parse or validate.
+func BuildRegistry(root string) (*Registry, error) {
+	paths, err := ListScripts(root)
+	if err != nil {
+		return nil, fmt.Errorf("could not list scripts: %v", err)
+	}
+
+	registry := &Registry{}
+	for _, path := range paths {
+		session, err := ReadSessionConfig(path)
+		if err != nil {
+			fmt.Printf("Warning: skipping %s: %v\n", path, err)
+			continue
+		}
+		registry.entries = append(registry.entries, ScriptEntry{Path: path, Session: session})
+	}
+
+	return registry, nil
+}
+
+// Entries returns every script known to the registry.
+func (r *Registry) Entries() []ScriptEntry {
+	return r.entries
+}
+
+// Find returns the entry whose session name or containing directory matches name.
+func (r *Registry) Find(name string) (*ScriptEntry, error) {
+	for i := range r.entries {
+		entry := &r.entries[i]
+		if entry.Session.SessionName == name || filepath.Base(filepath.Dir(entry.Path)) == name {
+			return entry, nil
+		}
+	}
+	return nil, fmt.Errorf("no script found matching %q", name)
+}
 
-	cmd := exec.Command("tmux", "attach-session", "-t", name)
-	cmd.Env = append(os.Environ(), "TERM=xterm-256color")
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	err := cmd.Run()
+// loadRegistry reads TP_DIRECTORY from the user config and builds a Registry from it.
+func loadRegistry() (*Registry, error) {
+	config, err := ReadConfig()
 	if err != nil {
-		return fmt.Errorf("failed to attach to session: %v", err)
+		return nil, fmt.Errorf("error reading configuration: %v", err)
+	}
+
+	root, ok := config["TP_DIRECTORY"]
+	if !ok {
+		return nil, fmt.Errorf("TP_DIRECTORY is not set in the configuration")
+	}
+
+	return BuildRegistry(root)
+}
+
+// createPanes splits targetWindow to create each of the window's additional
+// panes (pane 0 is the window itself), sends their commands, and zooms any
+// pane that requests it.
+func createPanes(t Tmux, window TmuxWindow, targetWindow, windowDir string) error {
+	for paneIndex, pane := range window.Panes {
+		splitFlag := "-h"
+		if pane.Split == "vertical" {
+			splitFlag = "-v"
+		}
+
+		paneDir := windowDir
+		if pane.Dir != "" {
+			paneDir = filepath.Join(windowDir, pane.Dir)
+		}
+
+		if err := t.SplitWindow(targetWindow, splitFlag, paneDir); err != nil {
+			return err
+		}
+
+		paneTarget := fmt.Sprintf("%s.%d", targetWindow, paneIndex+1)
+		for _, cmdStr := range pane.Commands {
+			if err := t.SendKeys(paneTarget, cmdStr); err != nil {
+				return err
+			}
+		}
+
+		if pane.Zoom {
+			if err := t.ResizePaneZoom(paneTarget); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -211,83 +528,213 @@ func initTmuxConfig() error {
 	return nil
 }
 
-func main() {
-	if len(os.Args) > 1 && os.Args[1] == "init" {
-		err := initTmuxConfig()
+// startAndAttach creates (or idempotently extends) the session and attaches
+// to it, switching to switch-client semantics when nested inside tmux and
+// attachFlag was requested.
+func startAndAttach(t Tmux, session *TmuxSession, basePath string, windowNames []string, attachFlag bool) error {
+	if err := createSession(t, session, basePath, windowNames); err != nil {
+		return fmt.Errorf("failed to create session: %v", err)
+	}
+
+	switchClient := attachFlag && os.Getenv("TMUX") != ""
+	if err := t.Attach(session.SessionName, switchClient); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// parseStartArgs parses the arguments following `teleport start`, e.g.
+// `<script-or-name> -w window1 -w window2 --attach`.
+func parseStartArgs(args []string) (target string, windowNames []string, attachFlag bool, err error) {
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-w":
+			if i+1 >= len(args) {
+				return "", nil, false, fmt.Errorf("-w requires a window name")
+			}
+			windowNames = append(windowNames, args[i+1])
+			i++
+		case "--attach":
+			attachFlag = true
+		default:
+			if target != "" {
+				return "", nil, false, fmt.Errorf("unexpected argument %q", args[i])
+			}
+			target = args[i]
+		}
+	}
+
+	if target == "" {
+		return "", nil, false, fmt.Errorf("usage: teleport start <script-or-name> [-w window]... [--attach]")
+	}
+
+	return target, windowNames, attachFlag, nil
+}
+
+// resolveScriptTarget resolves the argument to `teleport start`: a path to a
+// script file, or otherwise a project name looked up in the TP_DIRECTORY registry.
+func resolveScriptTarget(target string) (*TmuxSession, string, error) {
+	if stat, err := os.Stat(target); err == nil && !stat.IsDir() {
+		session, err := ReadSessionConfig(target)
 		if err != nil {
-			fmt.Println("Error initializing tmux config:", err)
-			os.Exit(1)
+			return nil, "", err
 		}
-		return
+		return session, filepath.Dir(target), nil
+	}
+
+	registry, err := loadRegistry()
+	if err != nil {
+		return nil, "", err
 	}
 
-	// Read the .tp.conf configuration
+	entry, err := registry.Find(target)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return entry.Session, filepath.Dir(entry.Path), nil
+}
+
+// runStop locates the script behind the named session and tears it down.
+func runStop(t Tmux, name string) error {
+	registry, err := loadRegistry()
+	if err != nil {
+		return err
+	}
+
+	entry, err := registry.Find(name)
+	if err != nil {
+		return err
+	}
+
+	return stopSession(t, entry.Session, filepath.Dir(entry.Path))
+}
+
+// runList enumerates every discovered script along with its session name and
+// whether that session is currently running.
+func runList(t Tmux) error {
+	registry, err := loadRegistry()
+	if err != nil {
+		return err
+	}
+
+	running := map[string]bool{}
+	if names, err := t.ListSessions(); err == nil {
+		for _, name := range names {
+			running[name] = true
+		}
+	}
+
+	for _, entry := range registry.Entries() {
+		marker := ""
+		if running[entry.Session.SessionName] {
+			marker = " (running)"
+		}
+		fmt.Printf("%s\t%s%s\n", entry.Path, entry.Session.SessionName, marker)
+	}
+
+	return nil
+}
+
+// runKill kills the named tmux session directly, without running any stop commands.
+func runKill(t Tmux, name string) error {
+	return t.KillSession(name)
+}
+
+// tmux is the Tmux implementation used by the running binary. Tests
+// substitute a fakeTmux instead of calling through commands directly.
+var tmux Tmux = execTmux{}
+
+// commands maps each subcommand name to its handler. A handler returning a
+// non-nil error causes main to print it and exit 1.
+var commands = map[string]func(args []string) error{
+	"init": func(args []string) error {
+		return initTmuxConfig()
+	},
+	"start": func(args []string) error {
+		target, windowNames, attachFlag, err := parseStartArgs(args)
+		if err != nil {
+			return err
+		}
+		session, basePath, err := resolveScriptTarget(target)
+		if err != nil {
+			return err
+		}
+		return startAndAttach(tmux, session, basePath, windowNames, attachFlag)
+	},
+	"stop": func(args []string) error {
+		if len(args) < 1 {
+			return fmt.Errorf("usage: teleport stop <session>")
+		}
+		return runStop(tmux, args[0])
+	},
+	"list": func(args []string) error {
+		return runList(tmux)
+	},
+	"kill": func(args []string) error {
+		if len(args) < 1 {
+			return fmt.Errorf("usage: teleport kill <session>")
+		}
+		return runKill(tmux, args[0])
+	},
+}
+
+// runDefault runs the classic fzf-driven flow: pick a script from TP_DIRECTORY and start it.
+func runDefault() error {
 	config, err := ReadConfig()
 	if err != nil {
-		fmt.Println("Error reading configuration:", err)
-		os.Exit(1)
+		return fmt.Errorf("error reading configuration: %v", err)
 	}
 
 	root, ok := config["TP_DIRECTORY"]
 	if !ok {
-		fmt.Println("TP_DIRECTORY is not set in the configuration")
-		os.Exit(1)
+		return fmt.Errorf("TP_DIRECTORY is not set in the configuration")
 	}
 
 	stat, err := os.Stat(root)
 	if err != nil || !stat.IsDir() {
-		fmt.Printf("%s is not a valid directory (%s)\n", "TP_DIRECTORY", root)
-		os.Exit(1)
+		return fmt.Errorf("%s is not a valid directory (%s)", "TP_DIRECTORY", root)
 	}
 
-	// List available .tmux files
 	scripts, err := ListScripts(root)
 	if err != nil {
-		fmt.Println("Error listing scripts:", err)
-		os.Exit(1)
+		return fmt.Errorf("error listing scripts: %v", err)
 	}
 
 	if len(scripts) == 0 {
-		fmt.Println("No .tmux scripts found.")
-		os.Exit(1)
+		return fmt.Errorf("no .tmux scripts found")
 	}
 
-	// Use fzf to select a script
 	selectedScript, err := SelectScriptWithFzf(scripts)
 	if err != nil {
-		fmt.Println("Error selecting script:", err)
-		os.Exit(1)
+		return fmt.Errorf("error selecting script: %v", err)
 	}
 
-	// Read the tmux session configuration
 	sessionConfig, err := ReadSessionConfig(selectedScript)
 	if err != nil {
-		fmt.Println("Error reading tmux session configuration:", err)
-		os.Exit(1)
+		return fmt.Errorf("error reading tmux session configuration: %v", err)
 	}
 
-	// Get the base path of the selected script to use as the directory in tmux windows
 	basePath := filepath.Dir(selectedScript)
 
-	// Check if the session already exists
-	if sessionExists(sessionConfig.SessionName) {
-		fmt.Printf("Session %s already exists. Attaching to it.\n", sessionConfig.SessionName)
-		err = attachSession(sessionConfig.SessionName)
-		if err != nil {
-			fmt.Printf("Error attaching to session: %v\n", err)
+	return startAndAttach(tmux, sessionConfig, basePath, nil, false)
+}
+
+func main() {
+	var err error
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			err = cmd(os.Args[2:])
+		} else {
+			err = runDefault()
 		}
 	} else {
-		// Create the session and attach
-		err = createSession(sessionConfig, basePath)
-		if err != nil {
-			fmt.Printf("Error creating session: %v\n", err)
-			os.Exit(1)
-		}
+		err = runDefault()
+	}
 
-		err = attachSession(sessionConfig.SessionName)
-		if err != nil {
-			fmt.Printf("Error attaching to session: %v\n", err)
-			os.Exit(1)
-		}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Tmux abstracts every tmux interaction teleport needs, so session/window
+// construction can be exercised in tests without a real tmux binary.
+type Tmux interface {
+	HasSession(name string) bool
+	NewSession(name string) error
+	RenameWindow(target, name string) error
+	NewWindow(session, name, dir string) error
+	SendKeys(target, keys string) error
+	SplitWindow(target, splitFlag, dir string) error
+	SelectLayout(target, layout string) error
+	ResizePaneZoom(target string) error
+	SelectWindow(target string) error
+	SelectPane(target string) error
+	ListWindows(session string) ([]string, error)
+	ListSessions() ([]string, error)
+	Attach(name string, switchClient bool) error
+	KillSession(name string) error
+}
+
+// execTmux is the default Tmux implementation: it shells out to the real
+// tmux binary on the PATH.
+type execTmux struct{}
+
+func (execTmux) HasSession(name string) bool {
+	return exec.Command("tmux", "has-session", "-t", name).Run() == nil
+}
+
+func (execTmux) NewSession(name string) error {
+	if err := exec.Command("tmux", "new-session", "-d", "-s", name).Run(); err != nil {
+		return fmt.Errorf("failed to create session: %v", err)
+	}
+	return nil
+}
+
+func (execTmux) RenameWindow(target, name string) error {
+	if err := exec.Command("tmux", "rename-window", "-t", target, name).Run(); err != nil {
+		return fmt.Errorf("failed to rename window: %v", err)
+	}
+	return nil
+}
+
+func (execTmux) NewWindow(session, name, dir string) error {
+	if err := exec.Command("tmux", "new-window", "-t", session, "-n", name, "-c", dir).Run(); err != nil {
+		return fmt.Errorf("failed to create window %q: %v", name, err)
+	}
+	return nil
+}
+
+func (execTmux) SendKeys(target, keys string) error {
+	if err := exec.Command("tmux", "send-keys", "-t", target, keys, "C-m").Run(); err != nil {
+		return fmt.Errorf("failed to send keys to %q: %v", target, err)
+	}
+	return nil
+}
+
+func (execTmux) SplitWindow(target, splitFlag, dir string) error {
+	if err := exec.Command("tmux", "split-window", splitFlag, "-t", target, "-c", dir).Run(); err != nil {
+		return fmt.Errorf("failed to split window %q: %v", target, err)
+	}
+	return nil
+}
+
+func (execTmux) SelectLayout(target, layout string) error {
+	if err := exec.Command("tmux", "select-layout", "-t", target, layout).Run(); err != nil {
+		return fmt.Errorf("failed to apply layout to %q: %v", target, err)
+	}
+	return nil
+}
+
+func (execTmux) ResizePaneZoom(target string) error {
+	if err := exec.Command("tmux", "resize-pane", "-Z", "-t", target).Run(); err != nil {
+		return fmt.Errorf("failed to zoom pane %q: %v", target, err)
+	}
+	return nil
+}
+
+func (execTmux) SelectWindow(target string) error {
+	if err := exec.Command("tmux", "select-window", "-t", target).Run(); err != nil {
+		return fmt.Errorf("failed to select window %q: %v", target, err)
+	}
+	return nil
+}
+
+func (execTmux) SelectPane(target string) error {
+	if err := exec.Command("tmux", "select-pane", "-t", target).Run(); err != nil {
+		return fmt.Errorf("failed to select pane %q: %v", target, err)
+	}
+	return nil
+}
+
+func (execTmux) ListWindows(session string) ([]string, error) {
+	out, err := exec.Command("tmux", "list-windows", "-t", session, "-F", "#W").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list windows: %v", err)
+	}
+	return splitNonEmptyLines(string(out)), nil
+}
+
+func (execTmux) ListSessions() ([]string, error) {
+	out, err := exec.Command("tmux", "list-sessions", "-F", "#S").Output()
+	if err != nil {
+		// No tmux server running is not an error worth surfacing; just no sessions.
+		return nil, nil
+	}
+	return splitNonEmptyLines(string(out)), nil
+}
+
+func (execTmux) Attach(name string, switchClient bool) error {
+	verb := "attach-session"
+	if switchClient {
+		verb = "switch-client"
+	}
+
+	fmt.Printf("Attempting to %s to session: %s\n", verb, name)
+
+	cmd := exec.Command("tmux", verb, "-t", name)
+	cmd.Env = append(os.Environ(), "TERM=xterm-256color")
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to %s: %v", verb, err)
+	}
+	return nil
+}
+
+func (execTmux) KillSession(name string) error {
+	if err := exec.Command("tmux", "kill-session", "-t", name).Run(); err != nil {
+		return fmt.Errorf("failed to kill session %q: %v", name, err)
+	}
+	return nil
+}
+
+func splitNonEmptyLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(s), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fakeTmux records every call made through the Tmux interface instead of
+// shelling out, so tests can assert the exact argv-equivalent sequence
+// createSession produces.
+type fakeTmux struct {
+	calls      []string
+	hasSession bool
+	windows    []string
+	sessions   []string
+}
+
+func (f *fakeTmux) record(method string, args ...string) {
+	f.calls = append(f.calls, fmt.Sprintf("%s(%s)", method, strings.Join(args, ",")))
+}
+
+func (f *fakeTmux) HasSession(name string) bool {
+	f.record("HasSession", name)
+	return f.hasSession
+}
+
+func (f *fakeTmux) NewSession(name string) error {
+	f.record("NewSession", name)
+	return nil
+}
+
+func (f *fakeTmux) RenameWindow(target, name string) error {
+	f.record("RenameWindow", target, name)
+	return nil
+}
+
+func (f *fakeTmux) NewWindow(session, name, dir string) error {
+	f.record("NewWindow", session, name, dir)
+	return nil
+}
+
+func (f *fakeTmux) SendKeys(target, keys string) error {
+	f.record("SendKeys", target, keys)
+	return nil
+}
+
+func (f *fakeTmux) SplitWindow(target, splitFlag, dir string) error {
+	f.record("SplitWindow", target, splitFlag, dir)
+	return nil
+}
+
+func (f *fakeTmux) SelectLayout(target, layout string) error {
+	f.record("SelectLayout", target, layout)
+	return nil
+}
+
+func (f *fakeTmux) ResizePaneZoom(target string) error {
+	f.record("ResizePaneZoom", target)
+	return nil
+}
+
+func (f *fakeTmux) SelectWindow(target string) error {
+	f.record("SelectWindow", target)
+	return nil
+}
+
+func (f *fakeTmux) SelectPane(target string) error {
+	f.record("SelectPane", target)
+	return nil
+}
+
+func (f *fakeTmux) ListWindows(session string) ([]string, error) {
+	f.record("ListWindows", session)
+	return f.windows, nil
+}
+
+func (f *fakeTmux) ListSessions() ([]string, error) {
+	f.record("ListSessions")
+	return f.sessions, nil
+}
+
+func (f *fakeTmux) Attach(name string, switchClient bool) error {
+	f.record("Attach", name, strconv.FormatBool(switchClient))
+	return nil
+}
+
+func (f *fakeTmux) KillSession(name string) error {
+	f.record("KillSession", name)
+	return nil
+}
+
+func sampleSession() *TmuxSession {
+	return &TmuxSession{
+		SessionName:  "proj",
+		SelectWindow: "server",
+		SelectPane:   "1",
+		Windows: []TmuxWindow{
+			{
+				Name:     "editor",
+				Commands: []string{"vim"},
+			},
+			{
+				Name:     "server",
+				Layout:   "main-vertical",
+				Commands: []string{"npm run dev"},
+				Panes: []TmuxPane{
+					{Split: "vertical", Commands: []string{"tail -f log"}},
+				},
+			},
+		},
+	}
+}
+
+func TestCreateSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmux    *fakeTmux
+		only    []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "new session with multi-pane window",
+			tmux: &fakeTmux{hasSession: false},
+			want: []string{
+				"HasSession(proj)",
+				"NewSession(proj)",
+				"RenameWindow(proj:0,editor)",
+				"SendKeys(proj:editor,cd /base)",
+				"SendKeys(proj:editor,vim)",
+				"NewWindow(proj,server,/base)",
+				"SendKeys(proj:server,cd /base)",
+				"SendKeys(proj:server,npm run dev)",
+				"SplitWindow(proj:server,-v,/base)",
+				"SendKeys(proj:server.1,tail -f log)",
+				"SelectLayout(proj:server,main-vertical)",
+				"SelectWindow(proj:server)",
+				"SelectPane(proj:server.1)",
+			},
+		},
+		{
+			name: "existing session skips already-running windows",
+			tmux: &fakeTmux{hasSession: true, windows: []string{"editor"}},
+			want: []string{
+				"HasSession(proj)",
+				"ListWindows(proj)",
+				"NewWindow(proj,server,/base)",
+				"SendKeys(proj:server,cd /base)",
+				"SendKeys(proj:server,npm run dev)",
+				"SplitWindow(proj:server,-v,/base)",
+				"SendKeys(proj:server.1,tail -f log)",
+				"SelectLayout(proj:server,main-vertical)",
+				"SelectWindow(proj:server)",
+				"SelectPane(proj:server.1)",
+			},
+		},
+		{
+			name: "only starts the named window",
+			tmux: &fakeTmux{hasSession: false},
+			only: []string{"server"},
+			want: []string{
+				"HasSession(proj)",
+				"NewSession(proj)",
+				"RenameWindow(proj:0,server)",
+				"SendKeys(proj:server,cd /base)",
+				"SendKeys(proj:server,npm run dev)",
+				"SplitWindow(proj:server,-v,/base)",
+				"SendKeys(proj:server.1,tail -f log)",
+				"SelectLayout(proj:server,main-vertical)",
+				"SelectWindow(proj:server)",
+				"SelectPane(proj:server.1)",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := createSession(tc.tmux, sampleSession(), "/base", tc.only)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("createSession() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(tc.tmux.calls, tc.want) {
+				t.Errorf("tmux calls =\n%s\nwant\n%s", strings.Join(tc.tmux.calls, "\n"), strings.Join(tc.want, "\n"))
+			}
+		})
+	}
+}